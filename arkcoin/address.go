@@ -0,0 +1,107 @@
+package arkcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/kristjank/ark-go/arkcoin/base58"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+//Errors returned by Decode and DecodeWIF.
+var (
+	ErrBadChecksum  = errors.New("arkcoin: base58check checksum mismatch")
+	ErrBadLength    = errors.New("arkcoin: invalid base58check payload length")
+	ErrWrongNetwork = errors.New("arkcoin: version byte does not match this network")
+)
+
+//Encode returns the Base58Check encoding of version||payload.
+func Encode(version byte, payload []byte) string {
+	return encodeCheck(append([]byte{version}, payload...))
+}
+
+//Decode verifies and strips the Base58Check checksum produced by Encode.
+func Decode(s string) (version byte, payload []byte, err error) {
+	b, err := decodeCheck(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(b) < 1 {
+		return 0, nil, ErrBadLength
+	}
+	return b[0], b[1:], nil
+}
+
+//DecodeWIF decodes a Base58Check WIF-encoded private key for param.
+func DecodeWIF(s string, param *Params) (*PrivateKey, error) {
+	version, payload, err := Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	ok := false
+	for _, h := range param.DumpedPrivateKeyHeader {
+		if version == h {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return nil, ErrWrongNetwork
+	}
+
+	isCompressed := false
+	switch len(payload) {
+	case btcec.PrivKeyBytesLen:
+	case btcec.PrivKeyBytesLen + 1:
+		if payload[btcec.PrivKeyBytesLen] != 0x01 {
+			return nil, ErrBadLength
+		}
+		payload = payload[:btcec.PrivKeyBytesLen]
+		isCompressed = true
+	default:
+		return nil, ErrBadLength
+	}
+
+	priv, pub := btcec.PrivKeyFromBytes(secp256k1, payload)
+	return &PrivateKey{
+		PrivateKey: priv,
+		PublicKey: &PublicKey{
+			PublicKey:    pub,
+			isCompressed: isCompressed,
+			param:        param,
+		},
+	}, nil
+}
+
+//encodeCheck base58-encodes payload with a trailing checksum.
+func encodeCheck(payload []byte) string {
+	checksum := doubleSHA256(payload)[:4]
+	return base58.Encode(append(payload, checksum...))
+}
+
+//decodeCheck base58-decodes s and verifies its trailing checksum.
+func decodeCheck(s string) ([]byte, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 5 {
+		return nil, ErrBadLength
+	}
+
+	payload, checksum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(doubleSHA256(payload)[:4], checksum) {
+		return nil, ErrBadChecksum
+	}
+	return payload, nil
+}
+
+//doubleSHA256 returns SHA256(SHA256(b)).
+func doubleSHA256(b []byte) []byte {
+	h1 := sha256.Sum256(b)
+	h2 := sha256.Sum256(h1[:])
+	return h2[:]
+}
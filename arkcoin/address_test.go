@@ -0,0 +1,20 @@
+package arkcoin
+
+import "testing"
+
+func TestDecodeWIFRoundTrip(t *testing.T) {
+	priv := NewPrivateKeyFromPassword("wif-key", testParams)
+	wif := priv.WIFAddress()
+
+	decoded, err := DecodeWIF(wif, testParams)
+	if err != nil {
+		t.Fatalf("DecodeWIF: %v", err)
+	}
+
+	if decoded.PublicKey.Address() != priv.PublicKey.Address() {
+		t.Fatalf("DecodeWIF address = %s, want %s", decoded.PublicKey.Address(), priv.PublicKey.Address())
+	}
+	if decoded.WIFAddress() != wif {
+		t.Fatalf("DecodeWIF did not round-trip: got %s, want %s", decoded.WIFAddress(), wif)
+	}
+}
@@ -0,0 +1,283 @@
+//Package hdkey implements BIP32 hierarchical deterministic key derivation on top of arkcoin.
+package hdkey
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/kristjank/ark-go/arkcoin"
+	"github.com/kristjank/ark-go/arkcoin/base58"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/ripemd160"
+)
+
+//HardenedKeyStart is the first hardened child index, as defined by BIP32.
+const HardenedKeyStart = uint32(0x80000000)
+
+//masterKeySeed is the HMAC key used to derive the master extended key.
+var masterKeySeed = []byte("Bitcoin seed")
+
+var secp256k1 = btcec.S256()
+
+//Errors returned by this package.
+var (
+	ErrInvalidSeedLength     = errors.New("hdkey: seed length must be between 16 and 64 bytes")
+	ErrHardenedPubDerivation = errors.New("hdkey: cannot derive a hardened child from a public key")
+	ErrInvalidChild          = errors.New("hdkey: derived key is invalid, try the next index")
+	ErrInvalidPath           = errors.New("hdkey: invalid derivation path")
+)
+
+//ExtendedPrivateKey represents a BIP32 extended private key (xprv).
+type ExtendedPrivateKey struct {
+	param       *arkcoin.Params
+	key         []byte
+	chainCode   []byte
+	depth       byte
+	parentFP    []byte
+	childNumber uint32
+}
+
+//ExtendedPublicKey represents a BIP32 extended public key (xpub).
+type ExtendedPublicKey struct {
+	param       *arkcoin.Params
+	key         []byte
+	chainCode   []byte
+	depth       byte
+	parentFP    []byte
+	childNumber uint32
+}
+
+//NewMasterKey derives the BIP32 master extended private key from a seed.
+func NewMasterKey(seed []byte, param *arkcoin.Params) (*ExtendedPrivateKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeedLength
+	}
+
+	mac := hmac.New(sha512.New, masterKeySeed)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	return &ExtendedPrivateKey{
+		param:       param,
+		key:         sum[:32],
+		chainCode:   sum[32:],
+		depth:       0,
+		parentFP:    []byte{0x00, 0x00, 0x00, 0x00},
+		childNumber: 0,
+	}, nil
+}
+
+//Child derives child key i using CKDpriv (hardened if i >= HardenedKeyStart).
+func (k *ExtendedPrivateKey) Child(i uint32) (*ExtendedPrivateKey, error) {
+	var data []byte
+	if i >= HardenedKeyStart {
+		data = append([]byte{0x00}, ser256(k.key)...)
+	} else {
+		data = serP(k.pubKeyBytes())
+	}
+	data = append(data, ser32(i)...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	il, ir := sum[:32], sum[32:]
+
+	ilNum := new(big.Int).SetBytes(il)
+	if ilNum.Cmp(secp256k1.N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	childNum := new(big.Int).Add(ilNum, new(big.Int).SetBytes(k.key))
+	childNum.Mod(childNum, secp256k1.N)
+	if childNum.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+
+	return &ExtendedPrivateKey{
+		param:       k.param,
+		key:         ser256(childNum.Bytes()),
+		chainCode:   ir,
+		depth:       k.depth + 1,
+		parentFP:    fingerprint(k.pubKeyBytes()),
+		childNumber: i,
+	}, nil
+}
+
+//Derive walks a BIP32 path such as "m/44'/111'/0'/0/0" from this key.
+func (k *ExtendedPrivateKey) Derive(path string) (*ExtendedPrivateKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := k
+	for _, index := range indices {
+		current, err = current.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return current, nil
+}
+
+//Neuter returns the extended public key counterpart of k.
+func (k *ExtendedPrivateKey) Neuter() *ExtendedPublicKey {
+	return &ExtendedPublicKey{
+		param:       k.param,
+		key:         k.pubKeyBytes(),
+		chainCode:   k.chainCode,
+		depth:       k.depth,
+		parentFP:    k.parentFP,
+		childNumber: k.childNumber,
+	}
+}
+
+//PrivateKey returns the arkcoin.PrivateKey for this extended key.
+func (k *ExtendedPrivateKey) PrivateKey() *arkcoin.PrivateKey {
+	return arkcoin.NewPrivateKey(k.key, k.param)
+}
+
+//String returns the Base58Check xprv serialization of k.
+func (k *ExtendedPrivateKey) String() string {
+	var buf bytes.Buffer
+	buf.Write(k.param.HDPrivateKeyID)
+	buf.WriteByte(k.depth)
+	buf.Write(k.parentFP)
+	buf.Write(ser32(k.childNumber))
+	buf.Write(k.chainCode)
+	buf.WriteByte(0x00)
+	buf.Write(ser256(k.key))
+	return base58CheckEncode(buf.Bytes())
+}
+
+func (k *ExtendedPrivateKey) pubKeyBytes() []byte {
+	return k.PrivateKey().PublicKey.Serialize()
+}
+
+//Child derives the non-hardened child public key at index i using CKDpub.
+func (k *ExtendedPublicKey) Child(i uint32) (*ExtendedPublicKey, error) {
+	if i >= HardenedKeyStart {
+		return nil, ErrHardenedPubDerivation
+	}
+
+	data := append(serP(k.key), ser32(i)...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	il, ir := sum[:32], sum[32:]
+
+	ilNum := new(big.Int).SetBytes(il)
+	if ilNum.Cmp(secp256k1.N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+
+	pub, err := btcec.ParsePubKey(k.key, secp256k1)
+	if err != nil {
+		return nil, err
+	}
+	ilx, ily := secp256k1.ScalarBaseMult(il)
+	x, y := secp256k1.Add(ilx, ily, pub.X, pub.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	childPub := (&btcec.PublicKey{Curve: secp256k1, X: x, Y: y}).SerializeCompressed()
+
+	return &ExtendedPublicKey{
+		param:       k.param,
+		key:         childPub,
+		chainCode:   ir,
+		depth:       k.depth + 1,
+		parentFP:    fingerprint(k.key),
+		childNumber: i,
+	}, nil
+}
+
+//PublicKey returns the arkcoin.PublicKey for this extended key.
+func (k *ExtendedPublicKey) PublicKey() (*arkcoin.PublicKey, error) {
+	return arkcoin.NewPublicKey(k.key, k.param)
+}
+
+//String returns the Base58Check xpub serialization of k.
+func (k *ExtendedPublicKey) String() string {
+	var buf bytes.Buffer
+	buf.Write(k.param.HDPublicKeyID)
+	buf.WriteByte(k.depth)
+	buf.Write(k.parentFP)
+	buf.Write(ser32(k.childNumber))
+	buf.Write(k.chainCode)
+	buf.Write(k.key)
+	return base58CheckEncode(buf.Bytes())
+}
+
+//ParsePath parses a BIP32 path such as "m/44'/111'/0'/0/0" into child indices.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidPath
+		}
+
+		index := uint32(n)
+		if hardened {
+			index += HardenedKeyStart
+		}
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+//ser32 is BIP32's ser32(i): a 4-byte big-endian serialization of i.
+func ser32(i uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, i)
+	return b
+}
+
+//ser256 is BIP32's ser256(k): a 32-byte big-endian, zero-padded serialization of k.
+func ser256(k []byte) []byte {
+	if len(k) >= 32 {
+		return k[len(k)-32:]
+	}
+	b := make([]byte, 32)
+	copy(b[32-len(k):], k)
+	return b
+}
+
+//serP is BIP32's serP(K): the SEC1 compressed serialization of a public key.
+func serP(pubKey []byte) []byte {
+	return pubKey
+}
+
+//fingerprint returns the first 4 bytes of RIPEMD160(SHA256(pubKey)).
+func fingerprint(pubKey []byte) []byte {
+	sum := sha256.Sum256(pubKey)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)[:4]
+}
+
+//base58CheckEncode base58-encodes payload with a trailing checksum.
+func base58CheckEncode(payload []byte) string {
+	h1 := sha256.Sum256(payload)
+	h2 := sha256.Sum256(h1[:])
+	return base58.Encode(append(payload, h2[:4]...))
+}
@@ -0,0 +1,85 @@
+package hdkey
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/kristjank/ark-go/arkcoin"
+)
+
+//bip32TestParams uses the standard Bitcoin mainnet xprv/xpub version bytes
+//so derived keys can be checked against the official BIP32 test vectors.
+var bip32TestParams = &arkcoin.Params{
+	HDPrivateKeyID: []byte{0x04, 0x88, 0xAD, 0xE4},
+	HDPublicKeyID:  []byte{0x04, 0x88, 0xB2, 0x1E},
+}
+
+//TestBIP32Vector1 checks master key and m/0' derivation against BIP32 test vector 1.
+func TestBIP32Vector1(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("decode seed: %v", err)
+	}
+
+	master, err := NewMasterKey(seed, bip32TestParams)
+	if err != nil {
+		t.Fatalf("NewMasterKey: %v", err)
+	}
+
+	wantXprv := "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+	wantXpub := "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+	if got := master.String(); got != wantXprv {
+		t.Fatalf("master xprv = %s, want %s", got, wantXprv)
+	}
+	if got := master.Neuter().String(); got != wantXpub {
+		t.Fatalf("master xpub = %s, want %s", got, wantXpub)
+	}
+
+	child, err := master.Child(HardenedKeyStart)
+	if err != nil {
+		t.Fatalf("Child(0'): %v", err)
+	}
+
+	wantChildXprv := "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7"
+	wantChildXpub := "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+	if got := child.String(); got != wantChildXprv {
+		t.Fatalf("m/0' xprv = %s, want %s", got, wantChildXprv)
+	}
+	if got := child.Neuter().String(); got != wantChildXpub {
+		t.Fatalf("m/0' xpub = %s, want %s", got, wantChildXpub)
+	}
+
+	viaDerive, err := master.Derive("m/0'")
+	if err != nil {
+		t.Fatalf("Derive: %v", err)
+	}
+	if viaDerive.String() != wantChildXprv {
+		t.Fatalf("Derive(m/0') = %s, want %s", viaDerive.String(), wantChildXprv)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	indices, err := ParsePath("m/44'/111'/0'/0/0")
+	if err != nil {
+		t.Fatalf("ParsePath: %v", err)
+	}
+	want := []uint32{
+		44 + HardenedKeyStart,
+		111 + HardenedKeyStart,
+		0 + HardenedKeyStart,
+		0,
+		0,
+	}
+	if len(indices) != len(want) {
+		t.Fatalf("got %d indices, want %d", len(indices), len(want))
+	}
+	for i := range want {
+		if indices[i] != want[i] {
+			t.Fatalf("index %d = %d, want %d", i, indices[i], want[i])
+		}
+	}
+
+	if _, err := ParsePath("44'/0"); err == nil {
+		t.Fatal("expected error for path missing leading \"m\"")
+	}
+}
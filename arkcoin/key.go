@@ -2,12 +2,9 @@ package arkcoin
 
 import (
 	"crypto/sha256"
-	"errors"
 	"fmt"
 	"log"
 
-	"github.com/kristjank/ark-go/arkcoin/base58"
-
 	"github.com/btcsuite/btcd/btcec"
 	"golang.org/x/crypto/ripemd160"
 )
@@ -23,12 +20,16 @@ type Params struct {
 	P2SHHeader             byte
 	HDPrivateKeyID         []byte
 	HDPublicKeyID          []byte
+	MessageMagic           string
 }
 
 //PublicKey represents public key for bitcoin
 type PublicKey struct {
 	*btcec.PublicKey
 	isCompressed bool
+	//pubKeyFormat records the original 0x06/0x07 hybrid prefix byte, if any,
+	//so Serialize can reproduce it. Zero for compressed/uncompressed keys.
+	pubKeyFormat byte
 	param        *Params
 }
 
@@ -40,53 +41,31 @@ type PrivateKey struct {
 
 //NewPublicKey returns PublicKey struct using public key hex string.
 func NewPublicKey(pubKeyByte []byte, param *Params) (*PublicKey, error) {
+	return NewAddressPubKey(pubKeyByte, param)
+}
+
+//NewAddressPubKey parses a serialized public key in any standard encoding
+//(compressed 0x02/0x03, uncompressed 0x04, or hybrid 0x06/0x07) and records
+//its original format so PublicKey.Serialize round-trips it exactly.
+func NewAddressPubKey(pubKeyByte []byte, param *Params) (*PublicKey, error) {
 	key, err := btcec.ParsePubKey(pubKeyByte, secp256k1)
 	if err != nil {
 		return nil, err
 	}
-	isCompressed := false
-	if len(pubKeyByte) == btcec.PubKeyBytesLenCompressed {
-		isCompressed = true
-	}
-	return &PublicKey{
-		PublicKey:    key,
-		isCompressed: isCompressed,
-		param:        param,
-	}, nil
-}
 
-//FromWIF gets PublicKey and PrivateKey from private key of WIF format.
-func FromWIF(wif string, param *Params) (*PrivateKey, error) {
-	pb, err := base58.Decode(wif)
-	if err != nil {
-		return nil, err
+	pub := &PublicKey{
+		PublicKey: key,
+		param:     param,
 	}
-	ok := false
-	for _, h := range param.DumpedPrivateKeyHeader {
-		if pb[0] == h {
-			ok = true
+	if len(pubKeyByte) > 0 {
+		switch pubKeyByte[0] {
+		case 0x02, 0x03:
+			pub.isCompressed = true
+		case 0x06, 0x07:
+			pub.pubKeyFormat = pubKeyByte[0]
 		}
 	}
-	if !ok {
-		return nil, errors.New("wif is invalid")
-	}
-	isCompressed := false
-	if len(pb) == btcec.PrivKeyBytesLen+2 && pb[btcec.PrivKeyBytesLen+1] == 0x01 {
-		pb = pb[:len(pb)-1]
-		isCompressed = true
-		log.Println("compressed")
-	}
-
-	//Get the raw public
-	priv, pub := btcec.PrivKeyFromBytes(secp256k1, pb[1:])
-	return &PrivateKey{
-		PrivateKey: priv,
-		PublicKey: &PublicKey{
-			PublicKey:    pub,
-			isCompressed: isCompressed,
-			param:        param,
-		},
-	}, nil
+	return pub, nil
 }
 
 //NewPrivateKeyFromPassword creates and returns PrivateKey from string.
@@ -153,14 +132,18 @@ func (priv *PrivateKey) WIFAddress() string {
 	if priv.PublicKey.isCompressed {
 		p = append(p, 0x1)
 	}
-	p = append(p, 0x0)
-	copy(p[1:], p[:len(p)-1])
-	p[0] = priv.PublicKey.param.DumpedPrivateKeyHeader[0]
-	return base58.Encode(p)
+	return Encode(priv.PublicKey.param.DumpedPrivateKeyHeader[0], p)
 }
 
-//Serialize serializes public key depending on isCompressed.
+//Serialize serializes public key depending on isCompressed and, for keys
+//parsed from a hybrid (0x06/0x07) encoding, the original hybrid format.
 func (pub *PublicKey) Serialize() []byte {
+	switch pub.pubKeyFormat {
+	case 0x06, 0x07:
+		b := pub.SerializeUncompressed()
+		b[0] = pub.pubKeyFormat
+		return b
+	}
 	if pub.isCompressed {
 		return pub.SerializeCompressed()
 	}
@@ -171,8 +154,7 @@ func (pub *PublicKey) Serialize() []byte {
 func (pub *PublicKey) AddressBytes() []byte {
 	//Next we get a sha256 hash of the public key generated
 	//via ECDSA, and then get a ripemd160 hash of the sha256 hash.
-	//shadPublicKeyBytes := sha256.Sum256(pub.Serialize())
-	shadPublicKeyBytes := pub.Serialize()
+	shadPublicKeyBytes := sha256.Sum256(pub.Serialize())
 
 	ripeHash := ripemd160.New()
 	if _, err := ripeHash.Write(shadPublicKeyBytes[:]); err != nil {
@@ -183,21 +165,17 @@ func (pub *PublicKey) AddressBytes() []byte {
 
 //Address returns bitcoin address from PublicKey
 func (pub *PublicKey) Address() string {
-	ripeHashedBytes := pub.AddressBytes()
-	ripeHashedBytes = append(ripeHashedBytes, 0x0)
-	copy(ripeHashedBytes[1:], ripeHashedBytes[:len(ripeHashedBytes)-1])
-	ripeHashedBytes[0] = pub.param.AddressHeader
-
-	return base58.Encode(ripeHashedBytes)
+	return Encode(pub.param.AddressHeader, pub.AddressBytes())
 }
 
-//DecodeAddress converts bitcoin address to hex form.
+//DecodeAddress converts bitcoin address to its raw payload bytes (version
+//byte stripped), verifying its Base58Check checksum.
 func DecodeAddress(addr string) ([]byte, error) {
-	pb, err := base58.Decode(addr)
+	_, payload, err := Decode(addr)
 	if err != nil {
 		return nil, err
 	}
-	return pb[1:], nil
+	return payload, nil
 }
 
 //Verify verifies signature is valid or not.
@@ -215,8 +193,7 @@ func (pub *PublicKey) Verify(signature []byte, data []byte) error {
 
 //AddressBytes returns ripeme160(sha256(redeem)) (address of redeem script).
 func AddressBytes(redeem []byte) []byte {
-	//h := sha256.Sum256(redeem)
-	h := redeem
+	h := sha256.Sum256(redeem)
 	ripeHash := ripemd160.New()
 	if _, err := ripeHash.Write(h[:]); err != nil {
 		log.Fatal(err)
@@ -224,12 +201,7 @@ func AddressBytes(redeem []byte) []byte {
 	return ripeHash.Sum(nil)
 }
 
-//Address returns ripeme160(sha256(redeem)) (address of redeem script).
+//Address returns the Base58Check address of redeem script.
 func Address(redeem []byte, header byte) string {
-	ripeHashedBytes := AddressBytes(redeem)
-	ripeHashedBytes = append(ripeHashedBytes, 0x0)
-	copy(ripeHashedBytes[1:], ripeHashedBytes[:len(ripeHashedBytes)-1])
-	ripeHashedBytes[0] = header
-
-	return base58.Encode(ripeHashedBytes)
+	return Encode(header, AddressBytes(redeem))
 }
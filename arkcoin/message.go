@@ -0,0 +1,88 @@
+package arkcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+//defaultMessageMagic is the prefix used when Params.MessageMagic is unset.
+const defaultMessageMagic = "ARK message:\n"
+
+//SignMessage signs message with priv and returns a base64-encoded recoverable signature.
+func SignMessage(priv *PrivateKey, message string) (string, error) {
+	hash := messageHash(priv.PublicKey.param, message)
+	sig, err := btcec.SignCompact(secp256k1, priv.PrivateKey, hash, priv.PublicKey.isCompressed)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+//VerifyMessage verifies that signature is a valid signature of message by address.
+func VerifyMessage(address, signature, message string, param *Params) error {
+	sigBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+
+	hash := messageHash(param, message)
+	pub, isCompressed, err := btcec.RecoverCompact(secp256k1, sigBytes, hash)
+	if err != nil {
+		return err
+	}
+
+	recovered := &PublicKey{
+		PublicKey:    pub,
+		isCompressed: isCompressed,
+		param:        param,
+	}
+	recoveredAddress := recovered.Address()
+	if recoveredAddress != address {
+		return fmt.Errorf("message signature does not match address: expected %s, recovered %s", address, recoveredAddress)
+	}
+	return nil
+}
+
+//messageHash returns the double-SHA256 hash signed/verified by SignMessage and VerifyMessage.
+func messageHash(param *Params, message string) []byte {
+	prefix := defaultMessageMagic
+	if param != nil && param.MessageMagic != "" {
+		prefix = param.MessageMagic
+	}
+
+	var buf bytes.Buffer
+	writeVarString(&buf, prefix)
+	writeVarString(&buf, message)
+
+	first := sha256.Sum256(buf.Bytes())
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+//writeVarString writes s to buf, prefixed with its length as a varint.
+func writeVarString(buf *bytes.Buffer, s string) {
+	writeVarInt(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+//writeVarInt writes n to buf using Bitcoin's CompactSize varint encoding.
+func writeVarInt(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n < 0xfd:
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xfd)
+		binary.Write(buf, binary.LittleEndian, uint16(n))
+	case n <= 0xffffffff:
+		buf.WriteByte(0xfe)
+		binary.Write(buf, binary.LittleEndian, uint32(n))
+	default:
+		buf.WriteByte(0xff)
+		binary.Write(buf, binary.LittleEndian, n)
+	}
+}
@@ -0,0 +1,21 @@
+package arkcoin
+
+import "testing"
+
+func TestSignVerifyMessageRoundTrip(t *testing.T) {
+	priv := NewPrivateKeyFromPassword("message-key", testParams)
+	address := priv.PublicKey.Address()
+
+	sig, err := SignMessage(priv, "hello ark")
+	if err != nil {
+		t.Fatalf("SignMessage: %v", err)
+	}
+
+	if err := VerifyMessage(address, sig, "hello ark", testParams); err != nil {
+		t.Fatalf("VerifyMessage: %v", err)
+	}
+
+	if err := VerifyMessage(address, sig, "tampered", testParams); err == nil {
+		t.Fatal("expected VerifyMessage to reject a tampered message")
+	}
+}
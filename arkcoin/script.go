@@ -0,0 +1,82 @@
+package arkcoin
+
+import (
+	"fmt"
+)
+
+//Script opcodes for a standard multisig redeem script.
+const (
+	opCheckMultisig byte = 0xae
+	op1             byte = 0x51
+	op16            byte = 0x60
+)
+
+//AddressKind classifies a decoded address as PKH or SH.
+type AddressKind int
+
+//Recognized AddressKind values.
+const (
+	AddressKindUnknown AddressKind = iota
+	AddressKindP2PKH
+	AddressKindP2SH
+)
+
+//BuildMultisigRedeemScript builds an OP_m <pub1>...<pubN> OP_n OP_CHECKMULTISIG redeem script.
+func BuildMultisigRedeemScript(m int, pubkeys []*PublicKey) ([]byte, error) {
+	n := len(pubkeys)
+	if m < 1 || m > 16 || n < 1 || n > 16 || m > n {
+		return nil, fmt.Errorf("arkcoin: invalid multisig parameters: %d-of-%d", m, n)
+	}
+
+	script := []byte{opN(m)}
+	for _, pub := range pubkeys {
+		pubBytes := pub.Serialize()
+		script = append(script, byte(len(pubBytes)))
+		script = append(script, pubBytes...)
+	}
+	script = append(script, opN(n), opCheckMultisig)
+
+	return script, nil
+}
+
+//opN returns the OP_1..OP_16 opcode for n.
+func opN(n int) byte {
+	return op1 - 1 + byte(n)
+}
+
+//P2SHAddress returns the Base58Check P2SH address for redeem.
+func P2SHAddress(redeem []byte, param *Params) string {
+	return Encode(param.P2SHHeader, AddressBytes(redeem))
+}
+
+//DecodeP2SHAddress validates addr as a P2SH address for param and returns its script hash.
+func DecodeP2SHAddress(addr string, param *Params) ([]byte, error) {
+	kind, payload, err := CheckAddress(addr, param)
+	if err != nil {
+		return nil, err
+	}
+	if kind != AddressKindP2SH {
+		return nil, fmt.Errorf("arkcoin: %s is not a P2SH address", addr)
+	}
+	return payload, nil
+}
+
+//CheckAddress verifies addr's checksum and classifies it as PKH or SH.
+func CheckAddress(addr string, param *Params) (AddressKind, []byte, error) {
+	version, payload, err := Decode(addr)
+	if err != nil {
+		return AddressKindUnknown, nil, err
+	}
+	if len(payload) != 20 {
+		return AddressKindUnknown, nil, ErrBadLength
+	}
+
+	switch version {
+	case param.AddressHeader:
+		return AddressKindP2PKH, payload, nil
+	case param.P2SHHeader:
+		return AddressKindP2SH, payload, nil
+	default:
+		return AddressKindUnknown, nil, ErrWrongNetwork
+	}
+}
@@ -0,0 +1,56 @@
+package arkcoin
+
+import "testing"
+
+var testParams = &Params{
+	DumpedPrivateKeyHeader: []byte{0xaa},
+	AddressHeader:          0x17,
+	P2SHHeader:             0x16,
+}
+
+func TestP2SHAddressRoundTrip(t *testing.T) {
+	priv1 := NewPrivateKeyFromPassword("redeem-key-1", testParams)
+	priv2 := NewPrivateKeyFromPassword("redeem-key-2", testParams)
+
+	redeem, err := BuildMultisigRedeemScript(2, []*PublicKey{priv1.PublicKey, priv2.PublicKey})
+	if err != nil {
+		t.Fatalf("BuildMultisigRedeemScript: %v", err)
+	}
+
+	addr := P2SHAddress(redeem, testParams)
+
+	kind, payload, err := CheckAddress(addr, testParams)
+	if err != nil {
+		t.Fatalf("CheckAddress: %v", err)
+	}
+	if kind != AddressKindP2SH {
+		t.Fatalf("expected AddressKindP2SH, got %v", kind)
+	}
+
+	hash := AddressBytes(redeem)
+	if string(payload) != string(hash) {
+		t.Fatalf("payload mismatch: got %x, want %x", payload, hash)
+	}
+
+	script, err := DecodeP2SHAddress(addr, testParams)
+	if err != nil {
+		t.Fatalf("DecodeP2SHAddress: %v", err)
+	}
+	if string(script) != string(hash) {
+		t.Fatalf("script hash mismatch: got %x, want %x", script, hash)
+	}
+}
+
+func TestAddressMatchesP2SHAddress(t *testing.T) {
+	redeem := []byte{op1, opCheckMultisig}
+
+	got := Address(redeem, testParams.P2SHHeader)
+	want := P2SHAddress(redeem, testParams)
+	if got != want {
+		t.Fatalf("Address(%x, header) = %s, want %s", redeem, got, want)
+	}
+
+	if _, _, err := CheckAddress(got, testParams); err != nil {
+		t.Fatalf("Address output failed CheckAddress: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package arkcoin
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+//Signature is a DER-decodable ECDSA signature.
+type Signature = btcec.Signature
+
+//SignRecoverable signs hash and returns the 65-byte compact recoverable signature.
+func (priv *PrivateKey) SignRecoverable(hash []byte) (sig [65]byte, err error) {
+	compact, err := btcec.SignCompact(secp256k1, priv.PrivateKey, hash, priv.PublicKey.isCompressed)
+	if err != nil {
+		return sig, err
+	}
+	copy(sig[:], compact)
+	return sig, nil
+}
+
+//SignDERLowS signs hash and returns a DER-encoded signature with S normalized
+//to the lower half of the curve order (S <= n/2). priv.Sign is already low-S
+//via btcec's RFC6979 signer, so this is a defensive no-op today.
+func (priv *PrivateKey) SignDERLowS(hash []byte) ([]byte, error) {
+	sig, err := priv.PrivateKey.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	halfOrder := new(big.Int).Rsh(secp256k1.N, 1)
+	if sig.S.Cmp(halfOrder) > 0 {
+		sig.S = new(big.Int).Sub(secp256k1.N, sig.S)
+	}
+	return sig.Serialize(), nil
+}
+
+//ParseSignature parses b as either a DER-encoded or 65-byte compact signature.
+func ParseSignature(b []byte) (*Signature, error) {
+	if len(b) == 65 {
+		return &Signature{
+			R: new(big.Int).SetBytes(b[1:33]),
+			S: new(big.Int).SetBytes(b[33:65]),
+		}, nil
+	}
+	return btcec.ParseSignature(b, secp256k1)
+}
+
+//Recover recovers the public key that produced sig over hash.
+func (pub *PublicKey) Recover(sig [65]byte, hash []byte) (*PublicKey, error) {
+	recovered, isCompressed, err := btcec.RecoverCompact(secp256k1, sig[:], hash)
+	if err != nil {
+		return nil, err
+	}
+	return &PublicKey{
+		PublicKey:    recovered,
+		isCompressed: isCompressed,
+		param:        pub.param,
+	}, nil
+}